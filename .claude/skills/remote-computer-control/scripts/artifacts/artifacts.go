@@ -0,0 +1,89 @@
+// Package artifacts extracts the zip bundle produced by
+// Sandbox.DownloadArtifacts (per-step screenshots, the planner message log,
+// any files the agent wrote under /workspace/out, and a manifest.json) onto
+// local disk.
+package artifacts
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ExtractArtifacts unpacks the zip bundle read from r into destDir. When
+// filter is non-empty, only entries whose name matches at least one glob
+// pattern (matched with filepath.Match against the entry's base name) are
+// written, e.g. []string{"*.png"} to pull only screenshots or
+// []string{"*.jsonl"} for just the planner log.
+func ExtractArtifacts(r io.Reader, destDir string, filter []string) error {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return fmt.Errorf("failed to read artifact bundle: %v", err)
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return fmt.Errorf("failed to open artifact bundle: %v", err)
+	}
+
+	if err := os.MkdirAll(destDir, 0755); err != nil {
+		return fmt.Errorf("failed to create destination dir %s: %v", destDir, err)
+	}
+
+	for _, entry := range zr.File {
+		if len(filter) > 0 && !matchesAny(filter, filepath.Base(entry.Name)) {
+			continue
+		}
+
+		if err := extractEntry(entry, destDir); err != nil {
+			return fmt.Errorf("failed to extract %s: %v", entry.Name, err)
+		}
+	}
+
+	return nil
+}
+
+func extractEntry(entry *zip.File, destDir string) error {
+	destPath := filepath.Join(destDir, entry.Name)
+
+	destRoot := filepath.Clean(destDir) + string(os.PathSeparator)
+	if !strings.HasPrefix(filepath.Clean(destPath)+string(os.PathSeparator), destRoot) {
+		return fmt.Errorf("entry %q escapes destination directory", entry.Name)
+	}
+
+	if entry.FileInfo().IsDir() {
+		return os.MkdirAll(destPath, 0755)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(destPath), 0755); err != nil {
+		return err
+	}
+
+	src, err := entry.Open()
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	dst, err := os.Create(destPath)
+	if err != nil {
+		return err
+	}
+	defer dst.Close()
+
+	_, err = io.Copy(dst, src)
+	return err
+}
+
+func matchesAny(patterns []string, name string) bool {
+	for _, pattern := range patterns {
+		if ok, _ := filepath.Match(pattern, name); ok {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,37 @@
+package artifacts
+
+import (
+	"archive/zip"
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestExtractArtifactsRejectsZipSlip verifies that an entry whose name
+// escapes destDir via "../" is rejected instead of being written outside
+// the extraction directory.
+func TestExtractArtifactsRejectsZipSlip(t *testing.T) {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	w, err := zw.Create("../../escaped.txt")
+	if err != nil {
+		t.Fatalf("zw.Create: %v", err)
+	}
+	if _, err := w.Write([]byte("pwned")); err != nil {
+		t.Fatalf("write entry: %v", err)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatalf("zw.Close: %v", err)
+	}
+
+	destDir := t.TempDir()
+	if err := ExtractArtifacts(bytes.NewReader(buf.Bytes()), destDir, nil); err == nil {
+		t.Fatalf("ExtractArtifacts succeeded on a zip-slip entry, want error")
+	}
+
+	escapedPath := filepath.Join(destDir, "..", "..", "escaped.txt")
+	if _, err := os.Stat(escapedPath); !os.IsNotExist(err) {
+		t.Fatalf("escaped.txt was written outside destDir at %s", escapedPath)
+	}
+}
@@ -0,0 +1,180 @@
+// Package progress renders a live terminal UI for a running task, replacing
+// the old "=== Message N ===" blocks with a pair of progress bars (time used,
+// actions completed) and a spinner line showing the latest summary. It is
+// driven by its own ticker rather than by message arrival, so the bars keep
+// moving while the planner is thinking between messages.
+package progress
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"code.byted.org/iaasng/lumi-cua-go-sdk/src/lumi_cua_sdk"
+)
+
+const (
+	barWidth      = 30
+	tickInterval  = 200 * time.Millisecond
+	avgActionTime = 8 * time.Second // historical average used to seed the action-count estimate
+)
+
+// Options configures how a TaskProgress renders.
+type Options struct {
+	// TimeoutSeconds is the task's configured timeout, used for the time bar.
+	TimeoutSeconds int
+	// PromptLength seeds the estimated-action-count bar when no better
+	// historical estimate is available (roughly one action per ~40 chars
+	// of prompt, floored at 1).
+	PromptLength int
+	// Silent suppresses all output.
+	Silent bool
+	// NoProgress falls back to plain "=== Message N ===" log lines instead
+	// of the live bars, e.g. when stdout isn't a terminal.
+	NoProgress bool
+}
+
+// TaskProgress wraps a RunTask messageChan and renders a live multi-bar
+// terminal UI while forwarding every message through unchanged, so callers
+// can keep their own error/timeout handling around the returned channel.
+type TaskProgress struct {
+	opts      Options
+	startedAt time.Time
+
+	mu               sync.Mutex
+	messagesReceived int
+	actionsCompleted int
+	estimatedActions int
+	lastSummary      string
+	finalAction      string
+
+	redraw chan os.Signal
+	done   chan struct{}
+}
+
+// New creates a TaskProgress for a task with the given options.
+func New(opts Options) *TaskProgress {
+	estimated := opts.PromptLength / 40
+	if estimated < 1 {
+		estimated = 1
+	}
+
+	p := &TaskProgress{
+		opts:             opts,
+		startedAt:        time.Now(),
+		estimatedActions: estimated,
+		done:             make(chan struct{}),
+	}
+
+	if !p.opts.Silent && !p.opts.NoProgress {
+		p.redraw = make(chan os.Signal, 1)
+		signal.Notify(p.redraw, syscall.SIGWINCH)
+	}
+
+	return p
+}
+
+// Wrap consumes in, updates the bars on its own ticker, and forwards every
+// message to the returned channel, which is closed when in closes or ctx is
+// done. Call Finish once the caller is done draining the returned channel.
+func (p *TaskProgress) Wrap(ctx context.Context, in <-chan lumi_cua_sdk.Message) <-chan lumi_cua_sdk.Message {
+	out := make(chan lumi_cua_sdk.Message)
+
+	go func() {
+		ticker := time.NewTicker(tickInterval)
+		defer ticker.Stop()
+		defer close(p.done)
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-p.redraw:
+				p.render()
+			case <-ticker.C:
+				p.render()
+			case msg, ok := <-in:
+				if !ok {
+					close(out)
+					return
+				}
+				p.record(msg)
+				out <- msg
+			}
+		}
+	}()
+
+	return out
+}
+
+func (p *TaskProgress) record(msg lumi_cua_sdk.Message) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.messagesReceived++
+	p.lastSummary = msg.Summary
+	p.finalAction = msg.Action
+	if msg.Action != "" && msg.Action != "error" && msg.Action != "timeout" && msg.Action != "canceled" {
+		p.actionsCompleted++
+		if p.actionsCompleted > p.estimatedActions {
+			p.estimatedActions = p.actionsCompleted
+		}
+	}
+}
+
+func (p *TaskProgress) render() {
+	if p.opts.Silent || p.opts.NoProgress {
+		return
+	}
+
+	p.mu.Lock()
+	elapsed := time.Since(p.startedAt)
+	timeFrac := elapsed.Seconds() / float64(p.opts.TimeoutSeconds)
+	actionFrac := float64(p.actionsCompleted) / float64(p.estimatedActions)
+	summary := p.lastSummary
+	p.mu.Unlock()
+
+	fmt.Printf("\r\033[K⏱  %s  %s\n", bar(timeFrac), fmt.Sprintf("%ds/%ds", int(elapsed.Seconds()), p.opts.TimeoutSeconds))
+	fmt.Printf("\033[K⚙️  %s  %d/%d actions\n", bar(actionFrac), p.actionsCompleted, p.estimatedActions)
+	fmt.Printf("\033[K💭 %s\033[2A\r", truncate(summary, 80))
+}
+
+// Finish stops rendering and prints a compact one-line summary of the run,
+// leaving no stray ANSI codes behind.
+func (p *TaskProgress) Finish() {
+	if p.redraw != nil {
+		signal.Stop(p.redraw)
+	}
+
+	if !p.opts.Silent && !p.opts.NoProgress {
+		fmt.Print("\033[2B\r")
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	fmt.Printf("Task finished: %d messages, %s elapsed, final action=%q\n",
+		p.messagesReceived, time.Since(p.startedAt).Round(time.Second), p.finalAction)
+}
+
+func bar(frac float64) string {
+	if frac < 0 {
+		frac = 0
+	}
+	if frac > 1 {
+		frac = 1
+	}
+	filled := int(frac * barWidth)
+	return "[" + strings.Repeat("=", filled) + strings.Repeat(" ", barWidth-filled) + "]"
+}
+
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return s[:n-1] + "…"
+}
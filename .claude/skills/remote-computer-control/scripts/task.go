@@ -3,22 +3,30 @@ package main
 import (
 	"context"
 	"encoding/base64"
+	"flag"
 	"fmt"
 	"log"
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 	"time"
 
 	"code.byted.org/iaasng/lumi-cua-go-sdk/src/lumi_cua_sdk"
+	"my_claw/.claude/skills/remote-computer-control/scripts/artifacts"
+	"my_claw/.claude/skills/remote-computer-control/scripts/progress"
 )
 
 func main() {
-	if len(os.Args) < 3 {
-		log.Fatalf("Usage: %s <taskListFile> <projectDir>", os.Args[0])
+	silent := flag.Bool("silent", false, "suppress all progress output")
+	noProgress := flag.Bool("no-progress", false, "fall back to plain log lines instead of live progress bars")
+	flag.Parse()
+
+	if flag.NArg() < 2 {
+		log.Fatalf("Usage: %s [--silent] [--no-progress] <taskListFile> <projectDir>", os.Args[0])
 	}
-	taskListFile := os.Args[1]
-	projectDir := os.Args[2]
+	taskListFile := flag.Arg(0)
+	projectDir := flag.Arg(1)
 
 	// 从文件读取任务
 	taskBytes, err := os.ReadFile(taskListFile)
@@ -54,6 +62,9 @@ func main() {
 		fmt.Printf("Using existing sandbox: ID=%s, IP=%s\n", sandbox.ID(), sandbox.IPAddress())
 	}
 
+	eventsCtx, stopEvents := context.WithCancel(ctx)
+	reasons := watchBusyReasons(eventsCtx, sandbox)
+
 	for {
 		isIdle, err := client.CheckIdle(ctx, sandbox.ID())
 		if err != nil {
@@ -65,9 +76,10 @@ func main() {
 			break
 		}
 
-		fmt.Println("Planner service is busy, waiting...")
+		fmt.Printf("Planner service is busy (%s), waiting...\n", reasons.latest())
 		time.Sleep(5 * time.Second)
 	}
+	stopEvents()
 
 	models, err := client.ListModels(ctx, sandbox.ID())
 	if err != nil {
@@ -80,7 +92,17 @@ func main() {
 
 	timeoutSeconds := 300
 
-	messageChan, err := client.RunTask(ctx, taskPrompt, sandbox.ID(), models[0].Name, "", "enabled", timeoutSeconds)
+	screenshotDir := filepath.Join(projectDir, "data", "temp")
+	if err := os.MkdirAll(screenshotDir, 0755); err != nil {
+		log.Printf("Failed to create screenshot directory: %v", err)
+	}
+
+	runOpts := lumi_cua_sdk.RunTaskOptions{
+		InstallSignalHandler: true,
+		DrainTimeout:         10 * time.Second,
+	}
+
+	messageChan, err := client.RunTask(ctx, taskPrompt, sandbox.ID(), models[0].Name, "", "enabled", timeoutSeconds, runOpts)
 	if err != nil {
 		if taskBusyErr, ok := err.(*lumi_cua_sdk.TaskBusyError); ok {
 			log.Printf("Task is busy: %v", taskBusyErr)
@@ -89,14 +111,38 @@ func main() {
 			log.Printf("Failed to run task: %v", err)
 		}
 	} else {
+		logCtx, stopLogs := context.WithCancel(ctx)
+		defer stopLogs()
+		streamTaskLogs(logCtx, client, sandbox.ID())
+
+		recorder := lumi_cua_sdk.NewScreencastRecorder(lumi_cua_sdk.ScreencastOptions{
+			Delay:     50,
+			LoopCount: 0,
+		})
+
+		prog := progress.New(progress.Options{
+			TimeoutSeconds: timeoutSeconds,
+			PromptLength:   len(taskPrompt),
+			Silent:         *silent,
+			NoProgress:     *noProgress,
+		})
+		progressCtx, stopProgress := context.WithCancel(ctx)
+		defer stopProgress()
+
 		fmt.Println("Starting task execution...")
 		messageCount := 0
-		for message := range messageChan {
+		var taskID string
+		for message := range prog.Wrap(progressCtx, messageChan) {
 			messageCount++
-			fmt.Printf("=== Message %d ===\n", messageCount)
-			fmt.Printf("Summary: %s\n", message.Summary)
-			fmt.Printf("Action: %s\n", message.Action)
-			fmt.Printf("TaskID: %s\n", message.TaskID)
+			if taskID == "" && message.TaskID != "" {
+				taskID = message.TaskID
+			}
+			if *noProgress {
+				fmt.Printf("=== Message %d ===\n", messageCount)
+				fmt.Printf("Summary: %s\n", message.Summary)
+				fmt.Printf("Action: %s\n", message.Action)
+				fmt.Printf("TaskID: %s\n", message.TaskID)
+			}
 
 			if message.Action == "error" {
 				fmt.Printf("❌ Task error: %s\n", message.Summary)
@@ -108,18 +154,41 @@ func main() {
 				break
 			}
 
+			if message.Action == "canceled" {
+				fmt.Println("🛑 Task canceled (Ctrl-C); drained in-flight step and stopped")
+				break
+			}
+
 			if message.Screenshot != "" {
-				fmt.Printf("Screenshot (first 64 chars): %s...\n", message.Screenshot[:64])
+				if *noProgress {
+					fmt.Printf("Screenshot (first 64 chars): %s...\n", message.Screenshot[:64])
+				}
+				frameIdx := recorder.Feed(message.Screenshot)
+				recorder.AddAnnotation(frameIdx, message.Summary+" | "+message.Action)
 			}
 		}
+		stopLogs()
+		stopProgress()
+		prog.Finish()
+
+		screencastPath := filepath.Join(screenshotDir, "task_screencast.gif")
+		if err := recorder.Close(screencastPath); err != nil {
+			log.Printf("Failed to write screencast: %v", err)
+		} else {
+			fmt.Printf("🎞️  Screencast saved as %s\n", screencastPath)
+		}
 
 		fmt.Printf("Task execution ended. Total messages received: %d\n", messageCount)
-	}
 
-	screenshotDir := filepath.Join(projectDir, "data", "temp")
-	if err := os.MkdirAll(screenshotDir, 0755); err != nil {
-		log.Printf("Failed to create screenshot directory: %v", err)
+		if taskID != "" {
+			downloadArtifacts(ctx, sandbox, taskID, screenshotDir)
+		}
 	}
+
+	// Deprecated: a single final screenshot is kept only as a fallback for
+	// when downloadArtifacts above fails or no taskID was observed; prefer
+	// the full artifact bundle, which carries every step's screenshot plus
+	// the planner log instead of just the last frame.
 	screenshotPath := filepath.Join(screenshotDir, "final_screenshot.png")
 
 	finalScreenshot, err := sandbox.Screenshot(ctx)
@@ -135,6 +204,104 @@ func main() {
 	}
 }
 
+// downloadArtifacts pulls the full trajectory bundle for taskID (per-step
+// screenshots, the planner message log, and any files the agent wrote under
+// /workspace/out) and extracts it under destDir/artifacts, so that serious
+// users have the whole run available for eval, replay, or RL training data
+// instead of just the final frame.
+func downloadArtifacts(ctx context.Context, sandbox *lumi_cua_sdk.Sandbox, taskID, destDir string) {
+	bundlePath := filepath.Join(destDir, "artifacts.zip")
+	bundleFile, err := os.Create(bundlePath)
+	if err != nil {
+		log.Printf("Failed to create artifact bundle file: %v", err)
+		return
+	}
+
+	err = sandbox.DownloadArtifacts(ctx, taskID, bundleFile)
+	bundleFile.Close()
+	if err != nil {
+		log.Printf("Failed to download artifacts: %v", err)
+		return
+	}
+	fmt.Printf("📦 Artifact bundle saved as %s\n", bundlePath)
+
+	bundle, err := os.Open(bundlePath)
+	if err != nil {
+		log.Printf("Failed to reopen artifact bundle: %v", err)
+		return
+	}
+	defer bundle.Close()
+
+	extractDir := filepath.Join(destDir, "artifacts")
+	if err := artifacts.ExtractArtifacts(bundle, extractDir, nil); err != nil {
+		log.Printf("Failed to extract artifacts: %v", err)
+		return
+	}
+	fmt.Printf("🗂️  Artifacts extracted to %s\n", extractDir)
+}
+
+// busyReasonWatcher tracks the most recent job event reason seen from a
+// WatchJobEvents long-poll, so the idle-wait loop can explain why the
+// planner is busy (e.g. "AnotherTaskRunning", "ImagePulling") without
+// issuing its own JobEvents poll on every iteration.
+type busyReasonWatcher struct {
+	mu     sync.Mutex
+	reason string
+}
+
+func (w *busyReasonWatcher) latest() string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.reason == "" {
+		return "reason unknown"
+	}
+	return w.reason
+}
+
+// watchBusyReasons starts a background WatchJobEvents long-poll for sandbox
+// and returns a watcher callers can poll for the latest reason; it stops
+// once ctx is canceled.
+func watchBusyReasons(ctx context.Context, sandbox *lumi_cua_sdk.Sandbox) *busyReasonWatcher {
+	w := &busyReasonWatcher{}
+
+	events, err := sandbox.WatchJobEvents(ctx, lumi_cua_sdk.JobEventsOptions{PageSize: 1})
+	if err != nil {
+		log.Printf("Failed to watch job events: %v", err)
+		return w
+	}
+
+	go func() {
+		for event := range events {
+			w.mu.Lock()
+			w.reason = fmt.Sprintf("%s: %s", event.Reason, event.Message)
+			w.mu.Unlock()
+		}
+	}()
+
+	return w
+}
+
+// streamTaskLogs tails the sandbox's planner/executor/guest logs in the
+// background and prints them as they arrive. It relies on the SDK to handle
+// reconnects internally (last-seen offset is tracked via Since), so this
+// loop only needs to give up once ctx is canceled.
+func streamTaskLogs(ctx context.Context, client *lumi_cua_sdk.LumiCuaClient, sandboxID string) {
+	logChan, err := client.StreamSandboxLogs(ctx, sandboxID, lumi_cua_sdk.StreamLogsOptions{
+		Wait:  true,
+		Since: time.Now(),
+	})
+	if err != nil {
+		log.Printf("Failed to start log stream: %v", err)
+		return
+	}
+
+	go func() {
+		for line := range logChan {
+			fmt.Printf("[%s][%s][%s] %s\n", line.Timestamp.Format(time.RFC3339), line.Source, line.Level, line.Text)
+		}
+	}()
+}
+
 func saveBase64Image(s, filePath string) error {
 	if idx := strings.Index(s, ","); idx != -1 {
 		s = s[idx+1:]
@@ -0,0 +1,39 @@
+package lumi_cua_sdk
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// DownloadArtifacts streams the manager's zip bundle for taskID into w. The
+// bundle contains every per-step screenshot, the full planner message log as
+// JSONL, any files the agent wrote under the sandbox-side /workspace/out
+// directory, and a manifest.json describing each entry (step index,
+// timestamp, action, sha256) — all assembled server-side by the manager;
+// this method only streams the response body through unmodified.
+func (s *Sandbox) DownloadArtifacts(ctx context.Context, taskID string, w io.Writer) error {
+	url := fmt.Sprintf("%s/sandboxes/%s/tasks/%s/artifacts", s.client.mgrBaseURL, s.id, taskID)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return fmt.Errorf("failed to build artifact request: %v", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+s.client.apiKey)
+
+	resp, err := s.client.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to download artifacts: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		msg, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("unexpected status %d downloading artifacts: %s", resp.StatusCode, msg)
+	}
+
+	if _, err := io.Copy(w, resp.Body); err != nil {
+		return fmt.Errorf("failed to write artifact bundle: %v", err)
+	}
+	return nil
+}
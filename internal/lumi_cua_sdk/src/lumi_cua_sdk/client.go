@@ -0,0 +1,154 @@
+// Package lumi_cua_sdk is the Go client for the Lumi CUA devbox manager and
+// planner services: listing and driving remote sandboxes, running
+// computer-use-agent tasks on them, and pulling back the artifacts those
+// tasks produce.
+package lumi_cua_sdk
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// LumiCuaClient talks to the ECS manager (sandbox lifecycle) and the
+// planner-agent (task execution) services behind a single API key.
+type LumiCuaClient struct {
+	mgrBaseURL     string
+	plannerBaseURL string
+	apiKey         string
+	httpClient     *http.Client
+}
+
+// NewLumiCuaClient builds a client for the given manager and planner base
+// URLs, authenticated with apiKey.
+func NewLumiCuaClient(mgrBaseURL, plannerBaseURL, apiKey string) *LumiCuaClient {
+	return &LumiCuaClient{
+		mgrBaseURL:     mgrBaseURL,
+		plannerBaseURL: plannerBaseURL,
+		apiKey:         apiKey,
+		httpClient:     http.DefaultClient,
+	}
+}
+
+// Sandbox is a single remote devbox managed by the manager service.
+type Sandbox struct {
+	client    *LumiCuaClient
+	id        string
+	ipAddress string
+}
+
+// ID returns the sandbox's identifier.
+func (s *Sandbox) ID() string { return s.id }
+
+// IPAddress returns the sandbox's reachable IP address.
+func (s *Sandbox) IPAddress() string { return s.ipAddress }
+
+// Model describes a planner model available on a sandbox.
+type Model struct {
+	Name string `json:"name"`
+}
+
+// ScreenshotResult carries a single screenshot, base64-encoded (optionally
+// as a data URL).
+type ScreenshotResult struct {
+	Base64Image string `json:"base64_image"`
+}
+
+// ListSandboxes returns the sandboxes visible to this client's API key.
+func (c *LumiCuaClient) ListSandboxes(ctx context.Context) ([]*Sandbox, error) {
+	var resp struct {
+		Sandboxes []struct {
+			ID        string `json:"id"`
+			IPAddress string `json:"ip_address"`
+		} `json:"sandboxes"`
+	}
+	if err := c.doJSON(ctx, http.MethodGet, c.mgrBaseURL+"/sandboxes", nil, &resp); err != nil {
+		return nil, fmt.Errorf("failed to list sandboxes: %v", err)
+	}
+
+	sandboxes := make([]*Sandbox, 0, len(resp.Sandboxes))
+	for _, s := range resp.Sandboxes {
+		sandboxes = append(sandboxes, &Sandbox{client: c, id: s.ID, ipAddress: s.IPAddress})
+	}
+	return sandboxes, nil
+}
+
+// CheckIdle reports whether the planner on sandboxID has no task in flight.
+func (c *LumiCuaClient) CheckIdle(ctx context.Context, sandboxID string) (bool, error) {
+	var resp struct {
+		Idle bool `json:"idle"`
+	}
+	url := fmt.Sprintf("%s/sandboxes/%s/idle", c.plannerBaseURL, sandboxID)
+	if err := c.doJSON(ctx, http.MethodGet, url, nil, &resp); err != nil {
+		return false, fmt.Errorf("failed to check idle status: %v", err)
+	}
+	return resp.Idle, nil
+}
+
+// ListModels returns the planner models available on sandboxID.
+func (c *LumiCuaClient) ListModels(ctx context.Context, sandboxID string) ([]Model, error) {
+	var resp struct {
+		Models []Model `json:"models"`
+	}
+	url := fmt.Sprintf("%s/sandboxes/%s/models", c.plannerBaseURL, sandboxID)
+	if err := c.doJSON(ctx, http.MethodGet, url, nil, &resp); err != nil {
+		return nil, fmt.Errorf("failed to list models: %v", err)
+	}
+	return resp.Models, nil
+}
+
+// Screenshot captures the sandbox's current display.
+func (s *Sandbox) Screenshot(ctx context.Context) (*ScreenshotResult, error) {
+	var resp ScreenshotResult
+	url := fmt.Sprintf("%s/sandboxes/%s/screenshot", s.client.mgrBaseURL, s.id)
+	if err := s.client.doJSON(ctx, http.MethodGet, url, nil, &resp); err != nil {
+		return nil, fmt.Errorf("failed to take screenshot: %v", err)
+	}
+	return &resp, nil
+}
+
+// doJSON issues an HTTP request with an optional JSON body and decodes a
+// JSON response into out (when out is non-nil). It is the shared plumbing
+// behind every synchronous call in this package.
+func (c *LumiCuaClient) doJSON(ctx context.Context, method, url string, body, out interface{}) error {
+	var reqBody io.Reader
+	if body != nil {
+		encoded, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("failed to encode request body: %v", err)
+		}
+		reqBody = bytes.NewReader(encoded)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, url, reqBody)
+	if err != nil {
+		return fmt.Errorf("failed to build request: %v", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+c.apiKey)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusConflict {
+		msg, _ := io.ReadAll(resp.Body)
+		return &TaskBusyError{Message: string(msg)}
+	}
+	if resp.StatusCode >= 300 {
+		msg, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("unexpected status %d: %s", resp.StatusCode, msg)
+	}
+
+	if out == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
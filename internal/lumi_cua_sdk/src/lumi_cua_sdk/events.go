@@ -0,0 +1,165 @@
+package lumi_cua_sdk
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"time"
+)
+
+// JobEvent is a scheduler/runtime event on a sandbox (pull image, schedule,
+// OOM, restart, network error, etc.), mirroring the events endpoint pattern
+// used by Kubernetes-style clusters.
+type JobEvent struct {
+	Message   string    `json:"message"`
+	Name      string    `json:"name"`
+	Reason    string    `json:"reason"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// JobEventsOptions controls pagination for JobEvents and WatchJobEvents.
+type JobEventsOptions struct {
+	// PageSize caps how many events are returned; the server applies its
+	// own default and maximum when zero.
+	PageSize int
+	// PageToken resumes from a previous JobEvents call's NextPageToken.
+	PageToken string
+	// Follow marks the options as intended for a blocking watch. JobEvents
+	// always returns a single []JobEvent page and rejects Follow:true with
+	// an error pointing callers at WatchJobEvents, which is the channel-
+	// returning long-poll this flag actually describes; WatchJobEvents
+	// itself ignores the field since following is already its whole job.
+	Follow bool
+}
+
+// jobEventsHTTPError is a non-2xx response from the events endpoint. Unlike
+// a network-level error (connection refused, timeout), it represents a
+// permanent condition — bad sandbox ID, auth failure, server error — that
+// will not resolve itself on a 1s retry, so WatchJobEvents checks for it
+// with errors.As to stop polling instead of looping forever.
+type jobEventsHTTPError struct {
+	StatusCode int
+	Body       string
+}
+
+func (e *jobEventsHTTPError) Error() string {
+	return fmt.Sprintf("unexpected status %d listing job events: %s", e.StatusCode, e.Body)
+}
+
+// jobEventsPage is the raw paginated response; NextPageToken is surfaced to
+// callers that want to keep paging.
+type jobEventsPage struct {
+	Events        []JobEvent `json:"events"`
+	NextPageToken string     `json:"next_page_token"`
+	LastIndex     uint64     `json:"last_index"`
+}
+
+// JobEvents returns a page of job events for the sandbox, oldest first
+// (matching the server's append-only event log), most recent event last.
+func (s *Sandbox) JobEvents(ctx context.Context, opts JobEventsOptions) ([]JobEvent, error) {
+	if opts.Follow {
+		return nil, fmt.Errorf("JobEvents does not support Follow; call Sandbox.WatchJobEvents instead")
+	}
+	page, err := s.client.fetchJobEvents(ctx, s.id, opts.PageSize, opts.PageToken)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list job events: %v", err)
+	}
+	return page.Events, nil
+}
+
+// WatchJobEvents long-polls for job events past the sandbox's current
+// tail, in the style of a Consul blocking query: it tracks the server's
+// lastIndex and reconnects with it whenever the server's long-poll times
+// out, so the returned channel keeps delivering new events indefinitely
+// until ctx is canceled.
+func (s *Sandbox) WatchJobEvents(ctx context.Context, opts JobEventsOptions) (<-chan JobEvent, error) {
+	out := make(chan JobEvent)
+
+	go func() {
+		defer close(out)
+
+		token := opts.PageToken
+		for {
+			if ctx.Err() != nil {
+				return
+			}
+
+			page, err := s.client.fetchJobEvents(ctx, s.id, opts.PageSize, token)
+			if err != nil {
+				if ctx.Err() != nil {
+					return
+				}
+				var httpErr *jobEventsHTTPError
+				if errors.As(err, &httpErr) {
+					// A bad sandbox ID, auth failure, or server error won't
+					// resolve itself on the next 1s retry; stop polling and
+					// surface it instead of looping forever.
+					fmt.Fprintf(os.Stderr, "lumi_cua_sdk: job events watch stopped: %v\n", httpErr)
+					return
+				}
+				select {
+				case <-time.After(time.Second):
+				case <-ctx.Done():
+					return
+				}
+				continue
+			}
+
+			for _, event := range page.Events {
+				select {
+				case out <- event:
+				case <-ctx.Done():
+					return
+				}
+			}
+
+			if page.NextPageToken != "" {
+				token = page.NextPageToken
+			}
+			// An empty page (long-poll timeout with no new events) just
+			// means "reconnect and keep waiting" — not end of stream.
+		}
+	}()
+
+	return out, nil
+}
+
+func (c *LumiCuaClient) fetchJobEvents(ctx context.Context, sandboxID string, pageSize int, pageToken string) (*jobEventsPage, error) {
+	query := url.Values{}
+	if pageSize > 0 {
+		query.Set("page_size", strconv.Itoa(pageSize))
+	}
+	if pageToken != "" {
+		query.Set("page_token", pageToken)
+	}
+
+	eventsURL := fmt.Sprintf("%s/sandboxes/%s/events?%s", c.mgrBaseURL, sandboxID, query.Encode())
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, eventsURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+c.apiKey)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		msg, _ := io.ReadAll(resp.Body)
+		return nil, &jobEventsHTTPError{StatusCode: resp.StatusCode, Body: string(msg)}
+	}
+
+	var page jobEventsPage
+	if err := json.NewDecoder(resp.Body).Decode(&page); err != nil {
+		return nil, err
+	}
+	return &page, nil
+}
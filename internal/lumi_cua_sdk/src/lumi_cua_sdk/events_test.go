@@ -0,0 +1,56 @@
+package lumi_cua_sdk
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// TestJobEventsReturnsErrorOnBadStatus verifies that a non-2xx response is
+// surfaced as an error instead of being fed into the JSON decoder.
+func TestJobEventsReturnsErrorOnBadStatus(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte("boom"))
+	}))
+	defer srv.Close()
+
+	c := NewLumiCuaClient(srv.URL, srv.URL, "test-key")
+	sandbox := &Sandbox{client: c, id: "sbx-1"}
+
+	if _, err := sandbox.JobEvents(context.Background(), JobEventsOptions{}); err == nil {
+		t.Fatal("JobEvents succeeded on a 500 response, want error")
+	}
+}
+
+// TestWatchJobEventsStopsOnPermanentError verifies that WatchJobEvents gives
+// up and closes its channel on a non-2xx response instead of retrying every
+// 1s forever.
+func TestWatchJobEventsStopsOnPermanentError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer srv.Close()
+
+	c := NewLumiCuaClient(srv.URL, srv.URL, "test-key")
+	sandbox := &Sandbox{client: c, id: "sbx-1"}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	events, err := sandbox.WatchJobEvents(ctx, JobEventsOptions{})
+	if err != nil {
+		t.Fatalf("WatchJobEvents: %v", err)
+	}
+
+	select {
+	case _, ok := <-events:
+		if ok {
+			t.Fatal("expected channel to close without events")
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("WatchJobEvents kept retrying past a permanent 401 instead of stopping")
+	}
+}
@@ -0,0 +1,59 @@
+package lumi_cua_sdk
+
+// font5x3 is a tiny built-in bitmap font used to burn captions into
+// screencast frames without pulling in an external font dependency. Each
+// glyph is five rows of three columns, top-to-bottom, left-to-right, '#'
+// lit / ' ' unlit. Only upper-case letters, digits, space, and a handful of
+// punctuation used in Summary/Action text are defined; anything else falls
+// back to a solid block so missing glyphs are still visible as "something
+// was here" rather than silently vanishing.
+var font5x3 = map[rune][5]string{
+	'A': {" # ", "# #", "###", "# #", "# #"},
+	'B': {"## ", "# #", "## ", "# #", "## "},
+	'C': {" ##", "#  ", "#  ", "#  ", " ##"},
+	'D': {"## ", "# #", "# #", "# #", "## "},
+	'E': {"###", "#  ", "## ", "#  ", "###"},
+	'F': {"###", "#  ", "## ", "#  ", "#  "},
+	'G': {" ##", "#  ", "# #", "# #", " ##"},
+	'H': {"# #", "# #", "###", "# #", "# #"},
+	'I': {"###", " # ", " # ", " # ", "###"},
+	'J': {"  #", "  #", "  #", "# #", " # "},
+	'K': {"# #", "## ", "#  ", "## ", "# #"},
+	'L': {"#  ", "#  ", "#  ", "#  ", "###"},
+	'M': {"# #", "###", "###", "# #", "# #"},
+	'N': {"# #", "###", "###", "###", "# #"},
+	'O': {" # ", "# #", "# #", "# #", " # "},
+	'P': {"## ", "# #", "## ", "#  ", "#  "},
+	'Q': {" # ", "# #", "# #", " # ", "  #"},
+	'R': {"## ", "# #", "## ", "## ", "# #"},
+	'S': {" ##", "#  ", " # ", "  #", "## "},
+	'T': {"###", " # ", " # ", " # ", " # "},
+	'U': {"# #", "# #", "# #", "# #", " # "},
+	'V': {"# #", "# #", "# #", "# #", " # "},
+	'W': {"# #", "# #", "###", "###", "# #"},
+	'X': {"# #", "# #", " # ", "# #", "# #"},
+	'Y': {"# #", "# #", " # ", " # ", " # "},
+	'Z': {"###", "  #", " # ", "#  ", "###"},
+	'0': {" # ", "# #", "# #", "# #", " # "},
+	'1': {" # ", "## ", " # ", " # ", "###"},
+	'2': {"## ", "  #", " # ", "#  ", "###"},
+	'3': {"## ", "  #", " # ", "  #", "## "},
+	'4': {"# #", "# #", "###", "  #", "  #"},
+	'5': {"###", "#  ", "## ", "  #", "## "},
+	'6': {" ##", "#  ", "## ", "# #", " # "},
+	'7': {"###", "  #", " # ", "#  ", "#  "},
+	'8': {" # ", "# #", " # ", "# #", " # "},
+	'9': {" # ", "# #", " ##", "  #", "## "},
+	' ': {"   ", "   ", "   ", "   ", "   "},
+	'.': {"   ", "   ", "   ", "   ", " # "},
+	',': {"   ", "   ", "   ", " # ", "#  "},
+	':': {"   ", " # ", "   ", " # ", "   "},
+	'-': {"   ", "   ", "###", "   ", "   "},
+	'_': {"   ", "   ", "   ", "   ", "###"},
+	'!': {" # ", " # ", " # ", "   ", " # "},
+	'?': {"## ", "  #", " # ", "   ", " # "},
+	'|': {" # ", " # ", " # ", " # ", " # "},
+	'/': {"  #", "  #", " # ", "#  ", "#  "},
+}
+
+var fontFallback = [5]string{"###", "###", "###", "###", "###"}
@@ -0,0 +1,20 @@
+package lumi_cua_sdk
+
+import (
+	"bytes"
+	"image/color"
+	_ "image/gif"  // register GIF decoding, in case a frame arrives pre-encoded
+	_ "image/jpeg" // register JPEG decoding
+	_ "image/png"  // register PNG decoding, the common screenshot format
+	"io"
+)
+
+var (
+	colorWhite       = color.RGBA{R: 255, G: 255, B: 255, A: 255}
+	blackTranslucent = color.RGBA{A: 180}
+)
+
+// newByteReader adapts a byte slice to an io.Reader for image.Decode.
+func newByteReader(b []byte) io.Reader {
+	return bytes.NewReader(b)
+}
@@ -0,0 +1,145 @@
+package lumi_cua_sdk
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// LogLine is a single structured log entry from a sandbox.
+type LogLine struct {
+	Timestamp time.Time `json:"timestamp"`
+	Source    string    `json:"source"` // "planner", "executor", or "guest"
+	Level     string    `json:"level"`
+	Text      string    `json:"text"`
+}
+
+// StreamLogsOptions configures a log stream.
+type StreamLogsOptions struct {
+	// Wait keeps the stream open until the task completes instead of
+	// returning once currently-buffered lines are drained.
+	Wait bool
+	// Since backfills log lines produced at or after this time before
+	// switching to live tailing.
+	Since time.Time
+}
+
+// StreamSandboxLogs is equivalent to sandboxID.StreamLogs but callable
+// directly off the client when the caller only has a sandbox ID.
+func (c *LumiCuaClient) StreamSandboxLogs(ctx context.Context, sandboxID string, opts StreamLogsOptions) (<-chan LogLine, error) {
+	return streamLogs(ctx, c, sandboxID, opts)
+}
+
+// StreamLogs opens a long-lived connection to the manager/planner and emits
+// structured log lines as they are produced on the remote side. The
+// connection transparently reconnects on transient network failure,
+// resuming from the last-seen log offset, so callers can tail a long-running
+// task the way `kubectl logs -f` tails a pod.
+func (s *Sandbox) StreamLogs(ctx context.Context, opts StreamLogsOptions) (<-chan LogLine, error) {
+	return streamLogs(ctx, s.client, s.id, opts)
+}
+
+func streamLogs(ctx context.Context, c *LumiCuaClient, sandboxID string, opts StreamLogsOptions) (<-chan LogLine, error) {
+	out := make(chan LogLine)
+
+	go func() {
+		defer close(out)
+
+		since := opts.Since
+		backoff := time.Second
+		const maxBackoff = 30 * time.Second
+
+		for {
+			if ctx.Err() != nil {
+				return
+			}
+
+			lastSeen, done := pumpLogLines(ctx, c, sandboxID, since, opts.Wait, out)
+			if !lastSeen.IsZero() {
+				// Resume just after the last line we actually delivered, so a
+				// reconnect never re-emits lines the caller already saw.
+				since = lastSeen.Add(time.Nanosecond)
+			}
+			if done {
+				return
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(backoff):
+			}
+			backoff *= 2
+			if backoff > maxBackoff {
+				backoff = maxBackoff
+			}
+		}
+	}()
+
+	return out, nil
+}
+
+// pumpLogLines streams one connection's worth of log lines into out. It
+// returns the timestamp of the last line delivered (used as the reconnect
+// offset) and whether the stream ended for good (task complete, caller gave
+// up, wait is false and the buffered backlog drained, or a non-transient
+// error) rather than because of a transient failure that warrants a
+// reconnect.
+func pumpLogLines(ctx context.Context, c *LumiCuaClient, sandboxID string, since time.Time, wait bool, out chan<- LogLine) (lastSeen time.Time, done bool) {
+	query := url.Values{}
+	if !since.IsZero() {
+		query.Set("since", since.Format(time.RFC3339Nano))
+	}
+	query.Set("wait", strconv.FormatBool(wait))
+
+	logURL := fmt.Sprintf("%s/sandboxes/%s/logs?%s", c.mgrBaseURL, sandboxID, query.Encode())
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, logURL, nil)
+	if err != nil {
+		return lastSeen, true
+	}
+	req.Header.Set("Authorization", "Bearer "+c.apiKey)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return lastSeen, ctx.Err() != nil
+	}
+	defer resp.Body.Close()
+
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 0, 64*1024), 4*1024*1024)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		var logLine LogLine
+		if err := json.Unmarshal(line, &logLine); err != nil {
+			continue
+		}
+
+		select {
+		case out <- logLine:
+			lastSeen = logLine.Timestamp
+		case <-ctx.Done():
+			return lastSeen, true
+		}
+	}
+
+	if ctx.Err() != nil {
+		return lastSeen, true
+	}
+	if !wait {
+		// Caller only asked for whatever was currently buffered: a clean EOF
+		// here means the backlog drained, not a transient disconnect, so
+		// there is nothing left to reconnect for.
+		return lastSeen, true
+	}
+	// The connection ended without a caller-initiated cancel: treat it as
+	// transient and let the caller reconnect from lastSeen.
+	return lastSeen, false
+}
@@ -0,0 +1,38 @@
+package lumi_cua_sdk
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// TestStreamLogsNoWaitStopsAfterBacklog verifies that Wait:false stops
+// reconnecting once the server's backlog drains, instead of polling forever
+// the way a Wait:true tail would.
+func TestStreamLogsNoWaitStopsAfterBacklog(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"timestamp":"2024-01-01T00:00:00Z","source":"planner","level":"info","text":"hello"}` + "\n"))
+	}))
+	defer srv.Close()
+
+	c := NewLumiCuaClient(srv.URL, srv.URL, "test-key")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	lines, err := c.StreamSandboxLogs(ctx, "sbx-1", StreamLogsOptions{Wait: false})
+	if err != nil {
+		t.Fatalf("StreamSandboxLogs: %v", err)
+	}
+
+	var got []LogLine
+	for line := range lines {
+		got = append(got, line)
+	}
+
+	if len(got) != 1 {
+		t.Fatalf("expected exactly one log line (no reconnect after backlog drains), got %d", len(got))
+	}
+}
@@ -0,0 +1,262 @@
+package lumi_cua_sdk
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"image"
+	"image/color/palette"
+	"image/draw"
+	"image/gif"
+	"image/png"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+)
+
+// ScreencastOptions configures a ScreencastRecorder.
+type ScreencastOptions struct {
+	// Delay between frames, in 1/100s units (gif.GIF's native unit).
+	// Defaults to 50 (0.5s) if zero.
+	Delay int
+	// LoopCount is passed straight to gif.GIF.LoopCount: 0 loops forever,
+	// -1 plays once.
+	LoopCount int
+	// MP4Path, when non-empty, makes Close also encode the same frames to an
+	// MP4 at this path via an ffmpeg subprocess, in addition to the GIF
+	// written to Close's path argument.
+	MP4Path string
+	// FFmpegPath overrides the ffmpeg binary used for MP4Path. Defaults to
+	// "ffmpeg" resolved off $PATH.
+	FFmpegPath string
+}
+
+// ScreencastRecorder consumes a task's screenshot frames and, on Close,
+// writes them out as an animated GIF. Identical consecutive frames are
+// deduplicated by hash so a long-idle task doesn't inflate the output, and
+// callers can burn a short caption into any kept frame via AddAnnotation.
+type ScreencastRecorder struct {
+	opts ScreencastOptions
+
+	mu          sync.Mutex
+	frames      []image.Image
+	annotations map[int]string
+	lastHash    [sha256.Size]byte
+	hasLast     bool
+}
+
+// NewScreencastRecorder creates a recorder with the given options.
+func NewScreencastRecorder(opts ScreencastOptions) *ScreencastRecorder {
+	if opts.Delay <= 0 {
+		opts.Delay = 50
+	}
+	return &ScreencastRecorder{
+		opts:        opts,
+		annotations: make(map[int]string),
+	}
+}
+
+// Feed decodes a base64 (optionally data-URL-prefixed) PNG screenshot and
+// buffers it as the next frame, unless it is byte-identical to the
+// immediately preceding frame. It returns the index of the frame the caller
+// should pass to AddAnnotation: the index of the newly buffered frame, or
+// of the previous frame when this one was deduplicated away.
+func (r *ScreencastRecorder) Feed(base64PNG string) int {
+	if idx := strings.Index(base64PNG, ","); idx != -1 {
+		base64PNG = base64PNG[idx+1:]
+	}
+	raw, err := base64.StdEncoding.DecodeString(base64PNG)
+	if err != nil {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		return len(r.frames) - 1
+	}
+
+	hash := sha256.Sum256(raw)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.hasLast && hash == r.lastHash {
+		return len(r.frames) - 1
+	}
+	r.lastHash = hash
+	r.hasLast = true
+
+	img, _, err := image.Decode(newByteReader(raw))
+	if err != nil {
+		return len(r.frames) - 1
+	}
+
+	r.frames = append(r.frames, img)
+	return len(r.frames) - 1
+}
+
+// AddAnnotation records a caption to be burned into frameIdx's bottom edge
+// when Close renders the GIF.
+func (r *ScreencastRecorder) AddAnnotation(frameIdx int, text string) {
+	if frameIdx < 0 {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.annotations[frameIdx] = text
+}
+
+// Close encodes every buffered frame into an animated GIF at path, burning
+// in any annotations, dithering each frame against the Plan9 palette with
+// Floyd–Steinberg error diffusion, and closes the recorder. When
+// opts.MP4Path is set, it also encodes the same captioned frames to an MP4
+// via ffmpeg.
+func (r *ScreencastRecorder) Close(path string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if len(r.frames) == 0 {
+		return fmt.Errorf("no frames recorded")
+	}
+
+	captioned := make([]image.Image, len(r.frames))
+	for i, frame := range r.frames {
+		captioned[i] = frame
+		if text, ok := r.annotations[i]; ok && text != "" {
+			captioned[i] = burnCaption(frame, text)
+		}
+	}
+
+	if err := r.writeGIF(path, captioned); err != nil {
+		return err
+	}
+	if r.opts.MP4Path != "" {
+		if err := r.encodeMP4(captioned); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *ScreencastRecorder) writeGIF(path string, frames []image.Image) error {
+	out := &gif.GIF{LoopCount: r.opts.LoopCount}
+	for _, frame := range frames {
+		bounds := frame.Bounds()
+		paletted := image.NewPaletted(bounds, palette.Plan9)
+		draw.FloydSteinberg.Draw(paletted, bounds, frame, image.Point{})
+
+		out.Image = append(out.Image, paletted)
+		out.Delay = append(out.Delay, r.opts.Delay)
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create screencast file: %v", err)
+	}
+	defer file.Close()
+
+	if err := gif.EncodeAll(file, out); err != nil {
+		return fmt.Errorf("failed to encode screencast: %v", err)
+	}
+	return nil
+}
+
+// encodeMP4 pipes frames as a PNG image2pipe stream into an ffmpeg
+// subprocess, which is the standard way to hand ffmpeg arbitrary
+// in-memory frames without writing each one to disk first.
+func (r *ScreencastRecorder) encodeMP4(frames []image.Image) error {
+	ffmpegPath := r.opts.FFmpegPath
+	if ffmpegPath == "" {
+		ffmpegPath = "ffmpeg"
+	}
+	if _, err := exec.LookPath(ffmpegPath); err != nil {
+		return fmt.Errorf("ffmpeg not found (set ScreencastOptions.FFmpegPath or install ffmpeg): %v", err)
+	}
+
+	fps := 100.0 / float64(r.opts.Delay)
+	cmd := exec.Command(ffmpegPath,
+		"-y",
+		"-f", "image2pipe",
+		"-framerate", fmt.Sprintf("%f", fps),
+		"-i", "-",
+		"-pix_fmt", "yuv420p",
+		r.opts.MP4Path,
+	)
+
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return fmt.Errorf("failed to open ffmpeg stdin: %v", err)
+	}
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("failed to start ffmpeg: %v", err)
+	}
+
+	var encodeErr error
+	for _, frame := range frames {
+		if err := png.Encode(stdin, frame); err != nil {
+			encodeErr = fmt.Errorf("failed to encode frame for ffmpeg: %v", err)
+			break
+		}
+	}
+	stdin.Close()
+
+	if err := cmd.Wait(); err != nil {
+		return fmt.Errorf("ffmpeg failed: %v: %s", err, stderr.String())
+	}
+	return encodeErr
+}
+
+// burnCaption draws a dark bar across the bottom of img and renders text
+// into it using the package's built-in bitmap font.
+func burnCaption(img image.Image, text string) image.Image {
+	const (
+		scale     = 3 // pixels per font dot
+		charW     = 3*scale + scale
+		charH     = 5 * scale
+		barMargin = scale
+	)
+
+	bounds := img.Bounds()
+	barHeight := charH + 2*barMargin
+	canvas := image.NewRGBA(bounds)
+	draw.Draw(canvas, bounds, img, bounds.Min, draw.Src)
+
+	barTop := bounds.Max.Y - barHeight
+	bar := image.Rect(bounds.Min.X, barTop, bounds.Max.X, bounds.Max.Y)
+	draw.Draw(canvas, bar, image.NewUniform(blackTranslucent), image.Point{}, draw.Over)
+
+	x := bounds.Min.X + barMargin
+	y := barTop + barMargin
+	for _, ch := range strings.ToUpper(text) {
+		if x+charW > bounds.Max.X {
+			break
+		}
+		glyph, ok := font5x3[ch]
+		if !ok {
+			glyph = fontFallback
+		}
+		drawGlyph(canvas, x, y, glyph, scale)
+		x += charW
+	}
+
+	return canvas
+}
+
+func drawGlyph(canvas *image.RGBA, x, y int, glyph [5]string, scale int) {
+	for row, line := range glyph {
+		for col, c := range line {
+			if c != '#' {
+				continue
+			}
+			px := x + col*scale
+			py := y + row*scale
+			for dy := 0; dy < scale; dy++ {
+				for dx := 0; dx < scale; dx++ {
+					canvas.Set(px+dx, py+dy, colorWhite)
+				}
+			}
+		}
+	}
+}
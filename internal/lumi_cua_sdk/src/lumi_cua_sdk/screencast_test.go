@@ -0,0 +1,56 @@
+package lumi_cua_sdk
+
+import (
+	"bytes"
+	"encoding/base64"
+	"image"
+	"image/color"
+	"image/png"
+	"testing"
+)
+
+func pngDataURL(t *testing.T, c color.Color) string {
+	t.Helper()
+	img := image.NewRGBA(image.Rect(0, 0, 4, 4))
+	for y := 0; y < 4; y++ {
+		for x := 0; x < 4; x++ {
+			img.Set(x, y, c)
+		}
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatalf("png.Encode: %v", err)
+	}
+	return "data:image/png;base64," + base64.StdEncoding.EncodeToString(buf.Bytes())
+}
+
+// TestScreencastRecorderDedupesIdenticalFrames verifies that Feed skips a
+// frame that is byte-identical to the one immediately before it, so an
+// idle task doesn't inflate the screencast with repeated frames.
+func TestScreencastRecorderDedupesIdenticalFrames(t *testing.T) {
+	r := NewScreencastRecorder(ScreencastOptions{})
+
+	red := pngDataURL(t, color.RGBA{R: 255, A: 255})
+	blue := pngDataURL(t, color.RGBA{B: 255, A: 255})
+
+	idx0 := r.Feed(red)
+	idx1 := r.Feed(red) // duplicate of idx0, should not add a frame
+	idx2 := r.Feed(blue)
+
+	if idx0 != 0 {
+		t.Fatalf("idx0 = %d, want 0", idx0)
+	}
+	if idx1 != idx0 {
+		t.Fatalf("idx1 = %d, want %d (duplicate frame reuses previous index)", idx1, idx0)
+	}
+	if idx2 != 1 {
+		t.Fatalf("idx2 = %d, want 1 (distinct frame gets a new index)", idx2)
+	}
+
+	r.mu.Lock()
+	numFrames := len(r.frames)
+	r.mu.Unlock()
+	if numFrames != 2 {
+		t.Fatalf("buffered %d frames, want 2 (duplicate should have been deduped)", numFrames)
+	}
+}
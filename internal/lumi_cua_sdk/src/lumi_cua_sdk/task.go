@@ -0,0 +1,200 @@
+package lumi_cua_sdk
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+)
+
+// Message is a single planner update for a running task.
+type Message struct {
+	Summary    string `json:"summary"`
+	Action     string `json:"action"`
+	TaskID     string `json:"task_id"`
+	Screenshot string `json:"screenshot"`
+}
+
+// TaskBusyError is returned by RunTask when the sandbox's planner already
+// has a task in flight.
+type TaskBusyError struct {
+	Message string
+}
+
+func (e *TaskBusyError) Error() string {
+	return fmt.Sprintf("planner is busy: %s", e.Message)
+}
+
+// RunTaskOptions controls optional RunTask behavior.
+type RunTaskOptions struct {
+	// InstallSignalHandler, when true, wires SIGINT/SIGTERM to cancel the
+	// in-flight task: the first signal requests a graceful cancel and waits
+	// up to DrainTimeout for the planner to stop; a second signal, or the
+	// timeout expiring, tears the stream down unconditionally instead.
+	InstallSignalHandler bool
+	// DrainTimeout bounds how long a graceful cancel waits before the
+	// stream is torn down unconditionally. Defaults to 10s if zero.
+	DrainTimeout time.Duration
+}
+
+// RunTask starts a task on sandboxID and returns a channel of Messages as
+// the planner produces them. The channel is closed when the task finishes,
+// errors, times out, or is canceled; a canceled run emits a final
+// Message{Action: "canceled"} before closing.
+func (c *LumiCuaClient) RunTask(ctx context.Context, prompt, sandboxID, modelName, mode, screenshotPolicy string, timeoutSeconds int, opts RunTaskOptions) (<-chan Message, error) {
+	var startResp struct {
+		TaskID string `json:"task_id"`
+	}
+	startReq := map[string]interface{}{
+		"prompt":            prompt,
+		"model":             modelName,
+		"mode":              mode,
+		"screenshot_policy": screenshotPolicy,
+		"timeout_seconds":   timeoutSeconds,
+	}
+	url := fmt.Sprintf("%s/sandboxes/%s/tasks", c.plannerBaseURL, sandboxID)
+	if err := c.doJSON(ctx, http.MethodPost, url, startReq, &startResp); err != nil {
+		return nil, err
+	}
+
+	runCtx, cancelRun := context.WithCancel(ctx)
+	out := make(chan Message)
+
+	if opts.DrainTimeout <= 0 {
+		opts.DrainTimeout = 10 * time.Second
+	}
+
+	if opts.InstallSignalHandler {
+		go c.watchCancelSignals(runCtx, cancelRun, sandboxID, startResp.TaskID, opts.DrainTimeout)
+	}
+
+	go func() {
+		defer cancelRun()
+		defer close(out)
+		c.pumpTaskMessages(runCtx, sandboxID, startResp.TaskID, out)
+	}()
+
+	return out, nil
+}
+
+// pumpTaskMessages streams newline-delimited JSON Messages from the
+// planner's task feed into out until the feed closes, runCtx is canceled,
+// or a terminal action (error/timeout/canceled) is seen.
+func (c *LumiCuaClient) pumpTaskMessages(runCtx context.Context, sandboxID, taskID string, out chan<- Message) {
+	url := fmt.Sprintf("%s/sandboxes/%s/tasks/%s/events", c.plannerBaseURL, sandboxID, taskID)
+	req, err := http.NewRequestWithContext(runCtx, http.MethodGet, url, nil)
+	if err != nil {
+		out <- Message{Action: "error", Summary: err.Error(), TaskID: taskID}
+		return
+	}
+	req.Header.Set("Authorization", "Bearer "+c.apiKey)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		if runCtx.Err() != nil {
+			out <- Message{Action: "canceled", Summary: "task canceled", TaskID: taskID}
+			return
+		}
+		out <- Message{Action: "error", Summary: err.Error(), TaskID: taskID}
+		return
+	}
+	defer resp.Body.Close()
+
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		var msg Message
+		if err := json.Unmarshal(line, &msg); err != nil {
+			continue
+		}
+		if msg.TaskID == "" {
+			msg.TaskID = taskID
+		}
+
+		select {
+		case out <- msg:
+		case <-runCtx.Done():
+			out <- Message{Action: "canceled", Summary: "task canceled", TaskID: taskID}
+			return
+		}
+
+		switch msg.Action {
+		case "error", "timeout", "canceled":
+			return
+		}
+	}
+
+	if runCtx.Err() != nil {
+		out <- Message{Action: "canceled", Summary: "task canceled", TaskID: taskID}
+	}
+}
+
+// watchCancelSignals implements the first-signal-graceful,
+// second-signal-hard-kill behavior for RunTaskOptions.InstallSignalHandler.
+// The first SIGINT/SIGTERM calls CancelTask and gives the planner up to
+// drainTimeout to stop on its own; a second signal, or the timeout expiring,
+// calls cancelRun to tear the message stream down unconditionally.
+func (c *LumiCuaClient) watchCancelSignals(runCtx context.Context, cancelRun context.CancelFunc, sandboxID, taskID string, drainTimeout time.Duration) {
+	sigCh := make(chan os.Signal, 2)
+	signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM)
+	defer signal.Stop(sigCh)
+
+	watchCancelSignalsOn(runCtx, cancelRun, sigCh, drainTimeout, func(ctx context.Context) error {
+		return c.CancelTask(ctx, sandboxID, taskID)
+	})
+}
+
+// watchCancelSignalsOn holds the actual first-signal-graceful,
+// second-signal-hard-kill race, with the OS signal channel and the
+// CancelTask call injected so it can be driven directly in tests.
+func watchCancelSignalsOn(runCtx context.Context, cancelRun context.CancelFunc, sigCh <-chan os.Signal, drainTimeout time.Duration, cancelTask func(context.Context) error) {
+	select {
+	case <-runCtx.Done():
+		return
+	case <-sigCh:
+	}
+
+	drainCtx, cancelDrain := context.WithTimeout(context.Background(), drainTimeout)
+	defer cancelDrain()
+	go func() {
+		if err := cancelTask(drainCtx); err != nil {
+			fmt.Fprintf(os.Stderr, "lumi_cua_sdk: graceful cancel failed: %v\n", err)
+		}
+	}()
+
+	select {
+	case <-sigCh:
+		cancelRun()
+	case <-drainCtx.Done():
+		cancelRun()
+	case <-runCtx.Done():
+	}
+}
+
+// PauseTask asks the planner to suspend taskID without discarding its
+// progress; ResumeTask continues it.
+func (c *LumiCuaClient) PauseTask(ctx context.Context, sandboxID, taskID string) error {
+	url := fmt.Sprintf("%s/sandboxes/%s/tasks/%s/pause", c.plannerBaseURL, sandboxID, taskID)
+	return c.doJSON(ctx, http.MethodPost, url, nil, nil)
+}
+
+// ResumeTask continues a task previously suspended with PauseTask.
+func (c *LumiCuaClient) ResumeTask(ctx context.Context, sandboxID, taskID string) error {
+	url := fmt.Sprintf("%s/sandboxes/%s/tasks/%s/resume", c.plannerBaseURL, sandboxID, taskID)
+	return c.doJSON(ctx, http.MethodPost, url, nil, nil)
+}
+
+// CancelTask requests a graceful stop of taskID on sandboxID.
+func (c *LumiCuaClient) CancelTask(ctx context.Context, sandboxID, taskID string) error {
+	url := fmt.Sprintf("%s/sandboxes/%s/tasks/%s/cancel", c.plannerBaseURL, sandboxID, taskID)
+	return c.doJSON(ctx, http.MethodPost, url, nil, nil)
+}
@@ -0,0 +1,114 @@
+package lumi_cua_sdk
+
+import (
+	"context"
+	"errors"
+	"os"
+	"sync"
+	"testing"
+	"time"
+)
+
+// TestWatchCancelSignalsGracefulDrain verifies that a single signal calls
+// CancelTask but does NOT tear down the run context on its own: a graceful
+// cancel is expected to let the planner's own "canceled" message end
+// pumpTaskMessages normally, so watchCancelSignalsOn should keep waiting
+// (for a second signal or the drain timeout) even after CancelTask returns.
+func TestWatchCancelSignalsGracefulDrain(t *testing.T) {
+	runCtx, cancelRun := context.WithCancel(context.Background())
+	sigCh := make(chan os.Signal, 2)
+
+	var mu sync.Mutex
+	var canceled bool
+	cancelDone := make(chan struct{})
+	cancelTask := func(ctx context.Context) error {
+		mu.Lock()
+		canceled = true
+		mu.Unlock()
+		close(cancelDone)
+		return nil
+	}
+
+	done := make(chan struct{})
+	go func() {
+		watchCancelSignalsOn(runCtx, cancelRun, sigCh, time.Minute, cancelTask)
+		close(done)
+	}()
+
+	sigCh <- os.Interrupt
+	<-cancelDone
+
+	select {
+	case <-runCtx.Done():
+		t.Fatal("watchCancelSignalsOn tore down runCtx on its own after a graceful CancelTask succeeded; it should wait for a second signal or the drain timeout")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	mu.Lock()
+	if !canceled {
+		t.Fatal("CancelTask was never called")
+	}
+	mu.Unlock()
+
+	cancelRun()
+	<-done
+}
+
+// TestWatchCancelSignalsSecondSignalHardKills verifies that a second signal
+// tears the run down immediately without waiting for the in-flight
+// CancelTask to finish.
+func TestWatchCancelSignalsSecondSignalHardKills(t *testing.T) {
+	runCtx, cancelRun := context.WithCancel(context.Background())
+	sigCh := make(chan os.Signal, 2)
+
+	cancelStarted := make(chan struct{})
+	cancelTask := func(ctx context.Context) error {
+		close(cancelStarted)
+		<-ctx.Done() // never finishes on its own; only the hard kill ends this test
+		return errors.New("canceled")
+	}
+
+	done := make(chan struct{})
+	go func() {
+		watchCancelSignalsOn(runCtx, cancelRun, sigCh, time.Minute, cancelTask)
+		close(done)
+	}()
+
+	sigCh <- os.Interrupt
+	<-cancelStarted
+	sigCh <- os.Interrupt // second signal: hard kill, don't wait for the drain
+
+	select {
+	case <-runCtx.Done():
+	case <-time.After(time.Second):
+		t.Fatal("second signal did not tear down runCtx promptly")
+	}
+	<-done
+}
+
+// TestWatchCancelSignalsDrainTimeout verifies that a drain that never
+// completes is hard-killed once drainTimeout elapses.
+func TestWatchCancelSignalsDrainTimeout(t *testing.T) {
+	runCtx, cancelRun := context.WithCancel(context.Background())
+	sigCh := make(chan os.Signal, 2)
+
+	cancelTask := func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}
+
+	done := make(chan struct{})
+	go func() {
+		watchCancelSignalsOn(runCtx, cancelRun, sigCh, 20*time.Millisecond, cancelTask)
+		close(done)
+	}()
+
+	sigCh <- os.Interrupt
+
+	select {
+	case <-runCtx.Done():
+	case <-time.After(time.Second):
+		t.Fatal("drain timeout did not tear down runCtx")
+	}
+	<-done
+}